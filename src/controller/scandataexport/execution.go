@@ -2,6 +2,7 @@ package scandataexport
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/orm"
 	q2 "github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scan/export"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/systemartifact"
 	"github.com/goharbor/harbor/src/pkg/task"
 )
@@ -29,6 +31,21 @@ type Controller interface {
 	ListExecutions(ctx context.Context, userName string) ([]*export.Execution, error)
 	GetTask(ctx context.Context, executionID int64) (*task.Task, error)
 	DeleteExecution(ctx context.Context, executionID int64) error
+
+	// Schedule registers request to run recurringly according to cron and
+	// returns the ID of the created schedule. Each firing starts a new
+	// execution via the same path as Start, tagged with
+	// task.ExecutionTriggerSchedule. Harbor keeps at most one schedule per
+	// user, mirroring the retention/GC scheduling model.
+	Schedule(ctx context.Context, request export.Request, cron string) (scheduleID int64, err error)
+	// ListSchedules returns the schedule registered for userID, if any.
+	ListSchedules(ctx context.Context, userID int) ([]*export.Schedule, error)
+	// UpdateSchedule replaces the schedule for request.UserID with the new
+	// cron spec and request.
+	UpdateSchedule(ctx context.Context, request export.Request, cron string) (scheduleID int64, err error)
+	// UnSchedule removes the schedule for userID; it does not affect
+	// executions it has already started.
+	UnSchedule(ctx context.Context, userID int) error
 }
 
 func NewController() Controller {
@@ -37,6 +54,7 @@ func NewController() Controller {
 		taskMgr:        task.Mgr,
 		makeCtx:        orm.Context,
 		sysArtifactMgr: systemartifact.Mgr,
+		scheduler:      scheduler.Sched,
 	}
 }
 
@@ -45,6 +63,7 @@ type controller struct {
 	taskMgr        task.Manager
 	makeCtx        func() context.Context
 	sysArtifactMgr systemartifact.Manager
+	scheduler      scheduler.Scheduler
 }
 
 func (c *controller) ListExecutions(ctx context.Context, userName string) ([]*export.Execution, error) {
@@ -111,12 +130,24 @@ func (c *controller) DeleteExecution(ctx context.Context, executionID int64) err
 }
 
 func (c *controller) Start(ctx context.Context, request export.Request) (executionID int64, err error) {
-	logger := log.GetLogger(ctx)
 	vendorID := int64(ctx.Value(export.CsvJobVendorIDKey).(int))
+	return c.start(ctx, request, vendorID, task.ExecutionTriggerManual)
+}
+
+func (c *controller) start(ctx context.Context, request export.Request, vendorID int64, trigger string) (executionID int64, err error) {
+	logger := log.GetLogger(ctx)
 	extraAttrs := make(map[string]interface{})
 	extraAttrs[export.JobNameAttribute] = request.JobName
 	extraAttrs[export.UserNameAttribute] = request.UserName
-	id, err := c.execMgr.Create(ctx, job.ScanDataExport, vendorID, task.ExecutionTriggerManual, extraAttrs)
+	extraAttrs[export.FormatKey] = string(request.Format)
+	if request.Notify != nil {
+		if data, err := json.Marshal(request.Notify); err != nil {
+			logger.Errorf("failed to encode notify config for scan data export, notification will be skipped: %v", err)
+		} else {
+			extraAttrs[export.NotifyAttribute] = string(data)
+		}
+	}
+	id, err := c.execMgr.Create(ctx, job.ScanDataExport, vendorID, trigger, extraAttrs)
 	logger.Infof("Created an execution record with id : %d for vendorID: %d", id, vendorID)
 	if err != nil {
 		logger.Errorf("Encountered error when creating job : %v", err)
@@ -128,6 +159,7 @@ func (c *controller) Start(ctx context.Context, request export.Request) (executi
 	params["JobId"] = id
 	params["Request"] = request
 	params[export.JobModeKey] = export.JobModeExport
+	params[export.FormatKey] = request.Format
 
 	j := &task.Job{
 		Name: job.ScanDataExport,
@@ -179,13 +211,19 @@ func (c *controller) convertToExportExecStatus(ctx context.Context, exec *task.E
 	if userName, ok := exec.ExtraAttrs[export.UserNameAttribute]; ok {
 		execStatus.UserName = userName.(string)
 	}
-	artifactExists := c.isCsvArtifactPresent(ctx, exec.ID, execStatus.ExportDataDigest)
+	format := export.FormatCSV
+	if f, ok := exec.ExtraAttrs[export.FormatKey]; ok && f.(string) != "" {
+		format = export.Format(f.(string))
+	}
+	execStatus.Format = format
+	execStatus.MimeType = export.MimeType(format)
+	artifactExists := c.isExportArtifactPresent(ctx, exec.ID, format, execStatus.ExportDataDigest)
 	execStatus.FilePresent = artifactExists
 	return execStatus
 }
 
-func (c *controller) isCsvArtifactPresent(ctx context.Context, execID int64, digest string) bool {
-	repositoryName := fmt.Sprintf("scandata_export_%v", execID)
+func (c *controller) isExportArtifactPresent(ctx context.Context, execID int64, format export.Format, digest string) bool {
+	repositoryName := export.RepositoryName(execID, format)
 	exists, err := c.sysArtifactMgr.Exists(ctx, strings.ToLower(export.Vendor), repositoryName, digest)
 	if err != nil {
 		exists = false
@@ -0,0 +1,131 @@
+package scandataexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notifier"
+	"github.com/goharbor/harbor/src/pkg/scan/export"
+)
+
+// notifyHTTPTimeout bounds how long a webhook or sink delivery is allowed to
+// take so a stalled remote endpoint cannot block the notifier dispatch.
+const notifyHTTPTimeout = 10 * time.Second
+
+var notifyHTTPClient = &http.Client{Timeout: notifyHTTPTimeout}
+
+func init() {
+	if err := notifier.Subscribe(export.TopicScanDataExportCompleted, &completionHandler{ctl: Ctl.(*controller)}); err != nil {
+		log.Errorf("failed to subscribe scan data export completion handler: %v", err)
+	}
+}
+
+// completionHandler delivers a scan data export Notify config once
+// TopicScanDataExportCompleted is published, the same way webhook/Slack/
+// email handlers subscribe to other Harbor event topics.
+type completionHandler struct {
+	ctl *controller
+}
+
+func (h *completionHandler) IsStateful() bool {
+	return false
+}
+
+func (h *completionHandler) Handle(ctx context.Context, value interface{}) error {
+	evt, ok := value.(*export.CompletedEvent)
+	if !ok {
+		return fmt.Errorf("invalid value for %s event: %T", export.TopicScanDataExportCompleted, value)
+	}
+	return h.ctl.deliverNotification(ctx, evt)
+}
+
+// deliverNotification sends the webhook and/or streams the artifact to the
+// sink configured on the Request that produced evt.ExecutionID, if any.
+func (c *controller) deliverNotification(ctx context.Context, evt *export.CompletedEvent) error {
+	logger := log.GetLogger(ctx)
+	exec, err := c.execMgr.Get(ctx, evt.ExecutionID)
+	if err != nil {
+		return err
+	}
+	raw, ok := exec.ExtraAttrs[export.NotifyAttribute]
+	if !ok {
+		return nil
+	}
+	var notify export.Notify
+	if err := json.Unmarshal([]byte(raw.(string)), &notify); err != nil {
+		logger.Errorf("failed to decode notify config for execution %d: %v", evt.ExecutionID, err)
+		return err
+	}
+	c.deliverWebhook(ctx, notify, evt)
+	if notify.Sink != nil {
+		c.streamToSink(ctx, notify.Sink, evt.ExecutionID, evt.Format, evt.Digest)
+	}
+	return nil
+}
+
+func (c *controller) deliverWebhook(ctx context.Context, notify export.Notify, evt *export.CompletedEvent) {
+	logger := log.GetLogger(ctx)
+	if notify.WebhookURL == "" {
+		return
+	}
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		logger.Errorf("failed to encode scan data export completion payload: %v", err)
+		return
+	}
+	headers := map[string]string{"Content-Type": "application/json"}
+	if notify.AuthHeader != "" {
+		headers["Authorization"] = notify.AuthHeader
+	}
+	if err := sendRequest(ctx, http.MethodPost, notify.WebhookURL, bytes.NewReader(payload), headers); err != nil {
+		logger.Errorf("failed to deliver scan data export completion webhook to %s: %v", notify.WebhookURL, err)
+	}
+}
+
+// streamToSink copies the export artifact for executionID to sink.URL via a
+// plain HTTP PUT, in addition to the copy already retained in the system
+// artifact store. sink.URL is expected to already carry whatever auth the
+// target requires, e.g. an S3/GCS pre-signed URL.
+func (c *controller) streamToSink(ctx context.Context, sink *export.Sink, executionID int64, format export.Format, digest string) {
+	logger := log.GetLogger(ctx)
+	repositoryName := export.RepositoryName(executionID, format)
+	_, reader, err := c.sysArtifactMgr.Read(ctx, strings.ToLower(export.Vendor), repositoryName, digest)
+	if err != nil {
+		logger.Errorf("failed to read scan data export artifact for execution %d: %v", executionID, err)
+		return
+	}
+	defer reader.Close()
+
+	if err := sendRequest(ctx, http.MethodPut, sink.URL, reader, nil); err != nil {
+		logger.Errorf("failed to stream scan data export artifact for execution %d to sink %s: %v", executionID, sink.URL, err)
+	}
+}
+
+// sendRequest issues an HTTP request against an externally configured
+// notify/sink URL, bounded by notifyHTTPTimeout, and treats any non-2xx
+// response as an error.
+func sendRequest(ctx context.Context, method, url string, body io.Reader, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := notifyHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("request returned status %d", resp.StatusCode)
+	}
+	return nil
+}
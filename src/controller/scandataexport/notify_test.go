@@ -0,0 +1,74 @@
+package scandataexport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goharbor/harbor/src/pkg/scan/export"
+)
+
+func TestDeliverWebhookPostsCompletedEvent(t *testing.T) {
+	var gotMethod, gotAuth, gotContentType string
+	var gotEvt export.CompletedEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotAuth = r.Header.Get("Authorization")
+		gotContentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&gotEvt); err != nil {
+			t.Errorf("failed to decode webhook payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := &controller{}
+	evt := &export.CompletedEvent{
+		ExecutionID: 42,
+		Status:      "Success",
+		Digest:      "sha256:abc",
+		DownloadURL: "/api/v2.0/export/cve/download/42",
+		Format:      export.FormatSarif,
+	}
+	c.deliverWebhook(context.Background(), export.Notify{WebhookURL: srv.URL, AuthHeader: "Bearer token"}, evt)
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("webhook method = %q, want POST", gotMethod)
+	}
+	if gotAuth != "Bearer token" {
+		t.Errorf("webhook Authorization header = %q, want %q", gotAuth, "Bearer token")
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("webhook Content-Type header = %q, want application/json", gotContentType)
+	}
+	if gotEvt != *evt {
+		t.Errorf("webhook payload = %+v, want %+v", gotEvt, *evt)
+	}
+}
+
+func TestDeliverWebhookSkippedWithoutURL(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	c := &controller{}
+	c.deliverWebhook(context.Background(), export.Notify{}, &export.CompletedEvent{ExecutionID: 1})
+	if called {
+		t.Error("deliverWebhook made a request even though WebhookURL was empty")
+	}
+}
+
+func TestSendRequestErrorsOnNonSuccessStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := sendRequest(context.Background(), http.MethodPost, srv.URL, nil, nil); err == nil {
+		t.Error("sendRequest expected an error for a 500 response, got nil")
+	}
+}
@@ -0,0 +1,188 @@
+package scandataexport
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/log"
+	q2 "github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/export"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+// schedulerCallback is the name under which the scan data export schedule
+// callback is registered with the scheduler. It is invoked by the scheduler
+// whenever a scan data export schedule fires, with the CallbackFuncParam
+// that was passed to Schedule() below, JSON-encoded.
+const schedulerCallback = "SCAN_DATA_EXPORT_SCHEDULE_CALLBACK"
+
+func init() {
+	scheduler.RegisterCallbackFunc(schedulerCallback, func(ctx context.Context, param string) error {
+		var request export.Request
+		if err := json.Unmarshal([]byte(param), &request); err != nil {
+			return err
+		}
+		return Ctl.(*controller).runScheduled(ctx, request)
+	})
+}
+
+// Schedule registers request to run recurringly according to cron. Harbor
+// keeps at most one active schedule per vendor ID, so a second call for the
+// same request.UserID replaces the previous schedule, mirroring how
+// retention/GC schedules work.
+func (c *controller) Schedule(ctx context.Context, request export.Request, cron string) (int64, error) {
+	vendorID := int64(request.UserID)
+	if err := c.scheduler.UnScheduleByVendor(ctx, export.Vendor, vendorID); err != nil {
+		log.GetLogger(ctx).Errorf("failed to unschedule previous scan data export schedule for user %d: %v", request.UserID, err)
+		return 0, err
+	}
+	// the scheduler JSON-encodes the callback param itself
+	scheduleID, err := c.scheduler.Schedule(ctx, export.Vendor, vendorID, "Custom", cron, schedulerCallback, request, nil)
+	if err != nil {
+		log.GetLogger(ctx).Errorf("failed to create schedule for scan data export: %v", err)
+		return 0, err
+	}
+	return scheduleID, nil
+}
+
+// ListSchedules returns the schedule registered for userID, if any. Harbor
+// keeps at most one schedule per vendor ID, so the result has at most one
+// element.
+func (c *controller) ListSchedules(ctx context.Context, userID int) ([]*export.Schedule, error) {
+	query := q2.New(q2.KeyWords{})
+	query.Keywords = map[string]interface{}{
+		"VendorType": export.Vendor,
+		"VendorID":   int64(userID),
+	}
+	schedules, err := c.scheduler.ListSchedules(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+	sched := schedules[0]
+	request, err := decodeScheduleRequest(sched.CallbackFuncParam)
+	if err != nil {
+		return nil, err
+	}
+	return []*export.Schedule{{
+		ID:       sched.ID,
+		Cron:     sched.Cron,
+		Request:  request,
+		UserName: request.UserName,
+	}}, nil
+}
+
+// UpdateSchedule replaces the schedule for request.UserID with one using the
+// new cron spec and request. The scheduler has no atomic update operation,
+// so this unschedules the old entry before scheduling the new one.
+func (c *controller) UpdateSchedule(ctx context.Context, request export.Request, cron string) (int64, error) {
+	if err := c.scheduler.UnScheduleByVendor(ctx, export.Vendor, int64(request.UserID)); err != nil {
+		log.GetLogger(ctx).Errorf("failed to unschedule previous scan data export schedule for user %d: %v", request.UserID, err)
+		return 0, err
+	}
+	return c.Schedule(ctx, request, cron)
+}
+
+// UnSchedule removes the schedule for userID; it does not affect executions
+// it has already started.
+func (c *controller) UnSchedule(ctx context.Context, userID int) error {
+	return c.scheduler.UnScheduleByVendor(ctx, export.Vendor, int64(userID))
+}
+
+func decodeScheduleRequest(param string) (export.Request, error) {
+	var request export.Request
+	err := json.Unmarshal([]byte(param), &request)
+	return request, err
+}
+
+// runScheduled starts an export execution for a fired schedule and enforces
+// the request's retention controls so recurring exports don't accumulate
+// unbounded artifacts in the system artifact store.
+func (c *controller) runScheduled(ctx context.Context, request export.Request) error {
+	vendorID := int64(request.UserID)
+	id, err := c.start(ctx, request, vendorID, task.ExecutionTriggerSchedule)
+	if err != nil {
+		return err
+	}
+	c.enforceRetention(ctx, request, vendorID, id)
+	return nil
+}
+
+// enforceRetention deletes the oldest *finished* executions (and their
+// artifacts) for vendorID beyond request.MaxExecutions, and purges finished
+// executions older than request.ArtifactTTL. Either control is skipped when
+// unset. A still-running execution (zero EndTime) is never purged.
+func (c *controller) enforceRetention(ctx context.Context, request export.Request, vendorID int64, latestExecutionID int64) {
+	logger := log.GetLogger(ctx)
+	if request.MaxExecutions <= 0 && request.ArtifactTTL <= 0 {
+		return
+	}
+	keywords := make(map[string]interface{})
+	keywords["VendorType"] = job.ScanDataExport
+	keywords["VendorID"] = vendorID
+	// only executions started by the schedule count; manual exports of the
+	// same user are left alone
+	keywords["Trigger"] = task.ExecutionTriggerSchedule
+	q := q2.New(q2.KeyWords{})
+	q.Keywords = keywords
+	q.Sorts = append(q.Sorts, &q2.Sort{Key: "StartTime", DESC: true})
+	execs, err := c.execMgr.List(ctx, q)
+	if err != nil {
+		logger.Errorf("failed to list executions while enforcing scan data export retention: %v", err)
+		return
+	}
+	for _, exec := range selectExecutionsToPurge(execs, request.MaxExecutions, request.ArtifactTTL, latestExecutionID, time.Now()) {
+		c.purgeExecutionArtifact(ctx, exec)
+		if err := c.execMgr.Delete(ctx, exec.ID); err != nil {
+			logger.Errorf("failed to purge scan data export execution %d during retention enforcement: %v", exec.ID, err)
+		}
+	}
+}
+
+// selectExecutionsToPurge returns the executions, among execs (expected to
+// be sorted newest first by StartTime), that are beyond maxExecutions finished
+// executions or older than ttl. A still-running execution (zero EndTime) is
+// never selected, and latestExecutionID is never selected by ttl so the
+// execution that just finished is never immediately purged by a tight TTL.
+func selectExecutionsToPurge(execs []*task.Execution, maxExecutions int, ttl time.Duration, latestExecutionID int64, now time.Time) []*task.Execution {
+	var toPurge []*task.Execution
+	kept := 0
+	for _, exec := range execs {
+		if exec.EndTime.IsZero() {
+			// still running, never purge
+			continue
+		}
+		kept++
+		expiredByCount := maxExecutions > 0 && kept > maxExecutions
+		expiredByTTL := ttl > 0 && exec.ID != latestExecutionID && now.Sub(exec.EndTime) > ttl
+		if !expiredByCount && !expiredByTTL {
+			continue
+		}
+		toPurge = append(toPurge, exec)
+	}
+	return toPurge
+}
+
+// purgeExecutionArtifact removes the system artifact produced by exec, if
+// any, ahead of deleting the execution record itself.
+func (c *controller) purgeExecutionArtifact(ctx context.Context, exec *task.Execution) {
+	logger := log.GetLogger(ctx)
+	digest, _ := exec.ExtraAttrs[export.DigestKey].(string)
+	if digest == "" {
+		return
+	}
+	format := export.FormatCSV
+	if f, ok := exec.ExtraAttrs[export.FormatKey]; ok {
+		format = export.Format(f.(string))
+	}
+	repositoryName := export.RepositoryName(exec.ID, format)
+	if err := c.sysArtifactMgr.Delete(ctx, strings.ToLower(export.Vendor), repositoryName, digest); err != nil {
+		logger.Errorf("failed to delete scan data export artifact for execution %d during retention enforcement: %v", exec.ID, err)
+	}
+}
@@ -0,0 +1,99 @@
+package scandataexport
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/scan/export"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+func TestDecodeScheduleRequestRoundTrip(t *testing.T) {
+	want := export.Request{
+		UserID:        1,
+		UserName:      "admin",
+		JobName:       "nightly-export",
+		Projects:      []int64{1, 2},
+		Format:        export.FormatSarif,
+		MaxExecutions: 5,
+		ArtifactTTL:   24 * time.Hour,
+	}
+	param, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	got, err := decodeScheduleRequest(string(param))
+	if err != nil {
+		t.Fatalf("decodeScheduleRequest returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeScheduleRequest round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeScheduleRequestInvalidJSON(t *testing.T) {
+	if _, err := decodeScheduleRequest("not json"); err == nil {
+		t.Error("decodeScheduleRequest with invalid JSON expected an error, got nil")
+	}
+}
+
+func mkExec(id int64, startedAgo, endedAgo time.Duration, now time.Time) *task.Execution {
+	e := &task.Execution{ID: id, StartTime: now.Add(-startedAgo)}
+	if endedAgo >= 0 {
+		e.EndTime = now.Add(-endedAgo)
+	}
+	return e
+}
+
+func TestSelectExecutionsToPurgeNeverSelectsRunningExecutions(t *testing.T) {
+	now := time.Now()
+	running := mkExec(1, time.Hour, -1, now)
+	got := selectExecutionsToPurge([]*task.Execution{running}, 1, 0, 0, now)
+	if len(got) != 0 {
+		t.Fatalf("expected a still-running execution to never be selected, got %v", got)
+	}
+}
+
+func TestSelectExecutionsToPurgeByCount(t *testing.T) {
+	now := time.Now()
+	execs := []*task.Execution{
+		mkExec(3, 1*time.Hour, 1*time.Hour, now),
+		mkExec(2, 2*time.Hour, 2*time.Hour, now),
+		mkExec(1, 3*time.Hour, 3*time.Hour, now),
+	}
+	got := selectExecutionsToPurge(execs, 2, 0, 3, now)
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("expected only the oldest execution beyond MaxExecutions=2 to be purged, got %v", idsOf(got))
+	}
+}
+
+func TestSelectExecutionsToPurgeByTTL(t *testing.T) {
+	now := time.Now()
+	execs := []*task.Execution{
+		mkExec(2, 10*time.Minute, 10*time.Minute, now),
+		mkExec(1, 2*time.Hour, 2*time.Hour, now),
+	}
+	got := selectExecutionsToPurge(execs, 0, time.Hour, 2, now)
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("expected only the execution older than the TTL to be purged, got %v", idsOf(got))
+	}
+}
+
+func TestSelectExecutionsToPurgeNeverSelectsLatestByTTL(t *testing.T) {
+	now := time.Now()
+	latest := mkExec(1, 2*time.Hour, 2*time.Hour, now)
+	got := selectExecutionsToPurge([]*task.Execution{latest}, 0, time.Hour, 1, now)
+	if len(got) != 0 {
+		t.Fatalf("expected latestExecutionID to never be purged by TTL, got %v", idsOf(got))
+	}
+}
+
+func idsOf(execs []*task.Execution) []int64 {
+	ids := make([]int64, len(execs))
+	for i, e := range execs {
+		ids[i] = e.ID
+	}
+	return ids
+}
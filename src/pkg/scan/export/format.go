@@ -0,0 +1,201 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Vendor is the vendor type used to register scan data export executions and
+// tasks with the task/execution managers as well as the system artifact store.
+const Vendor = "SCAN_DATA_EXPORT"
+
+// CtxKeyType is the type used for context keys defined by this package.
+type CtxKeyType string
+
+// CsvJobVendorIDKey is the context key under which the vendor ID for the
+// current export request is stashed by the API layer before invoking the
+// controller.
+const CsvJobVendorIDKey = CtxKeyType("vendorId")
+
+const (
+	// JobNameAttribute is the execution extra-attribute key holding the
+	// user-supplied name for the export job.
+	JobNameAttribute = "JobName"
+	// UserNameAttribute is the execution extra-attribute key holding the
+	// name of the user that requested the export.
+	UserNameAttribute = "UserName"
+	// DigestKey is the execution extra-attribute key holding the digest of
+	// the produced export artifact.
+	DigestKey = "Digest"
+
+	// JobModeKey is the job parameter key used to tell the scan data
+	// export job what mode it should run in.
+	JobModeKey = "JobMode"
+	// JobModeExport instructs the job to produce an export artifact.
+	JobModeExport = "export"
+
+	// FormatKey is the job parameter key holding the Format the export
+	// artifact should be rendered in.
+	FormatKey = "Format"
+)
+
+// Request captures the parameters of a scan data export request.
+type Request struct {
+	UserID   int
+	UserName string
+	JobName  string
+	Projects []int64
+	// Format is the output format of the export artifact, e.g. FormatCSV,
+	// FormatSarif, FormatSPDXJSON or FormatCycloneDXJSON. Defaults to
+	// FormatCSV when empty.
+	Format Format
+
+	// MaxExecutions caps the number of past executions kept for the
+	// schedule this request was created from. Zero means unbounded.
+	// Only meaningful for scheduled requests, see Controller.Schedule.
+	MaxExecutions int
+	// ArtifactTTL is how long the system artifact produced by a scheduled
+	// execution is kept before it is purged. Zero means unbounded.
+	ArtifactTTL time.Duration
+
+	// Notify, if set, is used to inform interested parties once the
+	// export execution reaches a terminal status.
+	Notify *Notify
+}
+
+// Execution reports the status of a scan data export execution.
+type Execution struct {
+	ID               int64
+	UserID           int64
+	Status           string
+	StatusMessage    string
+	Trigger          string
+	StartTime        time.Time
+	EndTime          time.Time
+	ExportDataDigest string
+	JobName          string
+	UserName         string
+	FilePresent      bool
+	// Format is the serialization format of the produced export artifact.
+	Format Format
+	// MimeType is the MIME type Format's artifact should be served with,
+	// see MimeType.
+	MimeType string
+}
+
+// Schedule describes a recurring scan data export job.
+type Schedule struct {
+	ID       int64
+	Cron     string
+	Request  Request
+	UserName string
+}
+
+// Format identifies the serialization format of a scan data export artifact.
+type Format string
+
+const (
+	// FormatCSV renders the export as a flat CSV file. This is the
+	// historical, default format.
+	FormatCSV Format = "csv"
+	// FormatSarif renders the export as a SARIF report.
+	FormatSarif Format = "sarif"
+	// FormatSPDXJSON renders the export as an SPDX SBOM document.
+	FormatSPDXJSON Format = "spdx-json"
+	// FormatCycloneDXJSON renders the export as a CycloneDX SBOM document.
+	FormatCycloneDXJSON Format = "cyclonedx-json"
+)
+
+// FormatMetadata describes the properties of an export artifact that depend
+// solely on its Format, such as the MIME type it should be served with.
+type FormatMetadata struct {
+	MimeType string
+}
+
+var formatMetadata = map[Format]FormatMetadata{
+	FormatCSV:           {MimeType: "text/csv"},
+	FormatSarif:         {MimeType: "application/sarif+json"},
+	FormatSPDXJSON:      {MimeType: "application/spdx+json"},
+	FormatCycloneDXJSON: {MimeType: "application/vnd.cyclonedx+json"},
+}
+
+// VulnerabilityRecord is a single row of the scan data being exported: one
+// vulnerability found in one artifact.
+type VulnerabilityRecord struct {
+	ProjectName    string
+	RepositoryName string
+	Digest         string
+	Tags           []string
+	CVEID          string
+	Package        string
+	Version        string
+	FixedVersion   string
+	Severity       string
+	CVSSScoreV3    float64
+	Description    string
+}
+
+// RenderContext carries the inputs a Renderer needs to produce an export
+// artifact.
+type RenderContext struct {
+	ExecutionID int64
+	Request     Request
+	Records     []VulnerabilityRecord
+	Writer      io.Writer
+}
+
+// Renderer produces the export artifact payload for a Format from the
+// underlying scan data.
+type Renderer interface {
+	// Render writes the export artifact for the given execution to the
+	// configured output and returns the number of top-level entries written:
+	// one per record for CSV, SARIF and CycloneDX, one per distinct
+	// repository/digest package for SPDX.
+	Render(ctx RenderContext) (int, error)
+}
+
+var renderers = map[Format]Renderer{}
+
+// RegisterRenderer registers the Renderer responsible for producing export
+// artifacts in the given Format. It is expected to be called from the init()
+// of the package implementing the renderer.
+func RegisterRenderer(format Format, renderer Renderer) {
+	renderers[format] = renderer
+}
+
+// GetRenderer returns the Renderer registered for format, defaulting to
+// FormatCSV's renderer for an empty format, or an error if no renderer has
+// been registered for it.
+func GetRenderer(format Format) (Renderer, error) {
+	if format == "" {
+		format = FormatCSV
+	}
+	renderer, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("no renderer registered for export format %q", format)
+	}
+	return renderer, nil
+}
+
+// MimeType returns the MIME type that artifacts of the given format should be
+// served with, defaulting to FormatCSV's MIME type for an empty or unknown
+// format.
+func MimeType(format Format) string {
+	if format == "" {
+		format = FormatCSV
+	}
+	if md, ok := formatMetadata[format]; ok {
+		return md.MimeType
+	}
+	return formatMetadata[FormatCSV].MimeType
+}
+
+// RepositoryName returns the name of the system artifact repository used to
+// store the export artifact produced for executionID.
+func RepositoryName(executionID int64, format Format) string {
+	if format == "" || format == FormatCSV {
+		return fmt.Sprintf("scandata_export_%v", executionID)
+	}
+	return fmt.Sprintf("scandata_export_%v_%s", executionID, format)
+}
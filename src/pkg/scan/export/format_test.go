@@ -0,0 +1,51 @@
+package export
+
+import "testing"
+
+func TestMimeType(t *testing.T) {
+	cases := []struct {
+		format Format
+		want   string
+	}{
+		{FormatCSV, "text/csv"},
+		{FormatSarif, "application/sarif+json"},
+		{FormatSPDXJSON, "application/spdx+json"},
+		{FormatCycloneDXJSON, "application/vnd.cyclonedx+json"},
+		{"", "text/csv"},
+		{Format("bogus"), "text/csv"},
+	}
+	for _, c := range cases {
+		if got := MimeType(c.format); got != c.want {
+			t.Errorf("MimeType(%q) = %q, want %q", c.format, got, c.want)
+		}
+	}
+}
+
+func TestRepositoryName(t *testing.T) {
+	cases := []struct {
+		format Format
+		want   string
+	}{
+		{FormatCSV, "scandata_export_1"},
+		{"", "scandata_export_1"},
+		{FormatSarif, "scandata_export_1_sarif"},
+		{FormatSPDXJSON, "scandata_export_1_spdx-json"},
+		{FormatCycloneDXJSON, "scandata_export_1_cyclonedx-json"},
+	}
+	for _, c := range cases {
+		if got := RepositoryName(1, c.format); got != c.want {
+			t.Errorf("RepositoryName(1, %q) = %q, want %q", c.format, got, c.want)
+		}
+	}
+}
+
+func TestGetRenderer(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatSarif, FormatSPDXJSON, FormatCycloneDXJSON, ""} {
+		if _, err := GetRenderer(format); err != nil {
+			t.Errorf("GetRenderer(%q) returned unexpected error: %v", format, err)
+		}
+	}
+	if _, err := GetRenderer(Format("bogus")); err == nil {
+		t.Error("GetRenderer(\"bogus\") expected an error, got nil")
+	}
+}
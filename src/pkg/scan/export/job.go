@@ -0,0 +1,139 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notifier"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+// Job implements job.Interface for the job.ScanDataExport job type. It is
+// registered with the job service during server bootstrap, the same way the
+// GC and retention job types are.
+type Job struct{}
+
+// MaxFails returns the number of retries the job service should attempt.
+func (j *Job) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency limits how many export jobs run at once; 0 means unbounded.
+func (j *Job) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry reports whether a failed export job should be retried.
+func (j *Job) ShouldRetry() bool {
+	return false
+}
+
+// Validate checks that params carries what Run needs.
+func (j *Job) Validate(params job.Parameters) error {
+	if _, ok := params["Request"]; !ok {
+		return fmt.Errorf("missing Request parameter for scan data export job")
+	}
+	return nil
+}
+
+// Run resolves the Renderer registered for the requested Format, renders
+// the scan data for the request's projects and stores the result as a
+// system artifact with the format's MIME type. Regardless of outcome, it
+// publishes TopicScanDataExportCompleted exactly once as it returns, so
+// delivery of request.Notify is driven by the job actually finishing rather
+// than by something later polling the execution's status.
+func (j *Job) Run(ctx job.Context, params job.Parameters) (err error) {
+	logger := ctx.GetLogger()
+	executionID, _ := params["JobId"].(int64)
+	request, ok := params["Request"].(Request)
+
+	var digest string
+	defer func() {
+		publishCompletion(ctx.SystemContext(), executionID, request.Format, digest, err)
+	}()
+
+	if !ok {
+		return fmt.Errorf("invalid Request parameter for scan data export job")
+	}
+
+	renderer, err := GetRenderer(request.Format)
+	if err != nil {
+		return err
+	}
+
+	records, err := vulnerabilityRecordsLoader(ctx.SystemContext(), request)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	n, err := renderer.Render(RenderContext{
+		ExecutionID: executionID,
+		Request:     request,
+		Records:     records,
+		Writer:      &buf,
+	})
+	if err != nil {
+		return err
+	}
+
+	repositoryName := RepositoryName(executionID, request.Format)
+	digest = sha256Digest(buf.Bytes())
+	artifact := &systemartifact.SystemArtifact{
+		VendorType:  strings.ToLower(Vendor),
+		Repository:  repositoryName,
+		Digest:      digest,
+		ContentType: MimeType(request.Format),
+		Size:        int64(buf.Len()),
+	}
+	if _, err := systemartifact.Mgr.Create(ctx.SystemContext(), artifact, bytes.NewReader(buf.Bytes())); err != nil {
+		return err
+	}
+
+	// Record the digest on the execution so later lookups (download, FilePresent
+	// checks, retention purging) can find the artifact this job just created.
+	if err := task.ExecMgr.UpdateExtraAttrs(ctx.SystemContext(), executionID, map[string]interface{}{
+		DigestKey: digest,
+		FormatKey: string(request.Format),
+	}); err != nil {
+		return err
+	}
+
+	logger.Infof("scan data export artifact created: repository=%s digest=%s format=%s entries=%d",
+		repositoryName, digest, request.Format, n)
+	return nil
+}
+
+// publishCompletion publishes TopicScanDataExportCompleted for executionID,
+// reporting "Error" if the job returned a non-nil error and "Success"
+// otherwise. It is the single point from which export completion is
+// announced; subscribers (e.g. the scandataexport controller's webhook/sink
+// delivery) decide what to do with it.
+func publishCompletion(ctx context.Context, executionID int64, format Format, digest string, jobErr error) {
+	status := "Success"
+	if jobErr != nil {
+		status = "Error"
+	}
+	evt := &CompletedEvent{
+		ExecutionID: executionID,
+		Status:      status,
+		Digest:      digest,
+		DownloadURL: fmt.Sprintf(downloadURLFormat, executionID),
+		Format:      format,
+	}
+	if err := notifier.Publish(TopicScanDataExportCompleted, evt); err != nil {
+		log.Errorf("failed to publish %s event for scan data export execution %d: %v", TopicScanDataExportCompleted, executionID, err)
+	}
+}
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
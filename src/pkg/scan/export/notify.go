@@ -0,0 +1,49 @@
+package export
+
+// downloadURLFormat renders the path of the existing scan data export
+// download endpoint for a given execution.
+const downloadURLFormat = "/api/v2.0/export/cve/download/%d"
+
+// TopicScanDataExportCompleted is the notifier topic published whenever a
+// scan data export execution reaches a terminal status, so that webhook
+// policies and other notifier handlers can react to it like any other
+// Harbor event.
+const TopicScanDataExportCompleted = "SCAN_DATA_EXPORT_COMPLETED"
+
+// NotifyAttribute is the execution extra-attribute key under which the
+// JSON-encoded Notify block of the originating Request is stashed, so it
+// can be recovered once the execution reaches a terminal status.
+const NotifyAttribute = "Notify"
+
+// Sink describes an external HTTP target the export artifact is PUT to in
+// addition to being stored as a system artifact. URL is expected to be a
+// plain or pre-signed URL (e.g. an S3/GCS pre-signed PUT URL) that accepts
+// an unauthenticated HTTP PUT of the artifact body; this package does not
+// implement S3/GCS request signing itself.
+type Sink struct {
+	URL string
+}
+
+// Notify describes how interested parties should be informed once a scan
+// data export execution completes.
+type Notify struct {
+	// WebhookURL receives a CompletedEvent as its JSON body.
+	WebhookURL string
+	// AuthHeader, if set, is sent verbatim as the Authorization header of
+	// the webhook request.
+	AuthHeader string
+	// Sink, if set, is where the produced artifact is streamed to in
+	// addition to the webhook notification.
+	Sink *Sink
+}
+
+// CompletedEvent is the payload delivered to Notify.WebhookURL and
+// published on TopicScanDataExportCompleted when an export execution
+// reaches a terminal status.
+type CompletedEvent struct {
+	ExecutionID int64
+	Status      string
+	Digest      string
+	DownloadURL string
+	Format      Format
+}
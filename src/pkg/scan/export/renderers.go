@@ -0,0 +1,236 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterRenderer(FormatCSV, csvRenderer{})
+	RegisterRenderer(FormatSarif, sarifRenderer{})
+	RegisterRenderer(FormatSPDXJSON, spdxJSONRenderer{})
+	RegisterRenderer(FormatCycloneDXJSON, cycloneDXJSONRenderer{})
+}
+
+var csvHeader = []string{
+	"Project", "Repository", "Digest", "Tags", "CVE ID", "Package", "Current Version",
+	"Fixed Version", "Severity", "CVSS V3 Score", "Description",
+}
+
+// csvRenderer renders scan data export artifacts as CSV, preserving the
+// historical, pre-multi-format behaviour.
+type csvRenderer struct{}
+
+func (csvRenderer) Render(ctx RenderContext) (int, error) {
+	w := csv.NewWriter(ctx.Writer)
+	if err := w.Write(csvHeader); err != nil {
+		return 0, err
+	}
+	for _, r := range ctx.Records {
+		record := []string{
+			r.ProjectName, r.RepositoryName, r.Digest, joinTags(r.Tags), r.CVEID,
+			r.Package, r.Version, r.FixedVersion, r.Severity, formatScore(r.CVSSScoreV3), r.Description,
+		}
+		if err := w.Write(record); err != nil {
+			return 0, err
+		}
+	}
+	w.Flush()
+	return len(ctx.Records), w.Error()
+}
+
+// sarifRenderer renders scan data export artifacts as a SARIF report, with
+// one SARIF "result" per vulnerability finding.
+type sarifRenderer struct{}
+
+func (sarifRenderer) Render(ctx RenderContext) (int, error) {
+	results := make([]sarifResult, 0, len(ctx.Records))
+	for _, r := range ctx.Records {
+		results = append(results, sarifResult{
+			RuleID: r.CVEID,
+			Level:  sarifLevel(r.Severity),
+			Message: sarifMessage{
+				Text: r.Description,
+			},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{
+						URI: r.RepositoryName + "@" + r.Digest,
+					},
+				},
+			}},
+		})
+	}
+	doc := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "harbor-scan-data-export", Version: "1.0.0"}},
+			Results: results,
+		}},
+	}
+	return len(ctx.Records), json.NewEncoder(ctx.Writer).Encode(doc)
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// spdxJSONRenderer renders scan data export artifacts as an SPDX SBOM
+// document, one SPDX package per distinct repository/digest pair.
+type spdxJSONRenderer struct{}
+
+func (spdxJSONRenderer) Render(ctx RenderContext) (int, error) {
+	seen := make(map[string]bool)
+	packages := make([]spdxPackage, 0, len(ctx.Records))
+	for _, r := range ctx.Records {
+		key := r.RepositoryName + "@" + r.Digest
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		packages = append(packages, spdxPackage{
+			SPDXID:           "SPDXRef-Package-" + key,
+			Name:             r.RepositoryName,
+			VersionInfo:      r.Digest,
+			DownloadLocation: "NOASSERTION",
+		})
+	}
+	doc := spdxDocument{
+		SPDXVersion:  "SPDX-2.3",
+		DataLicense:  "CC0-1.0",
+		SPDXID:       "SPDXRef-DOCUMENT",
+		Name:         "harbor-scan-data-export",
+		CreationInfo: spdxCreationInfo{Creators: []string{"Tool: harbor-scan-data-export"}},
+		Packages:     packages,
+	}
+	return len(packages), json.NewEncoder(ctx.Writer).Encode(doc)
+}
+
+type spdxDocument struct {
+	SPDXVersion  string           `json:"spdxVersion"`
+	DataLicense  string           `json:"dataLicense"`
+	SPDXID       string           `json:"SPDXID"`
+	Name         string           `json:"name"`
+	CreationInfo spdxCreationInfo `json:"creationInfo"`
+	Packages     []spdxPackage    `json:"packages"`
+}
+
+type spdxCreationInfo struct {
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string `json:"SPDXID"`
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	DownloadLocation string `json:"downloadLocation"`
+}
+
+// cycloneDXJSONRenderer renders scan data export artifacts as a CycloneDX
+// SBOM document with one vulnerability entry per finding.
+type cycloneDXJSONRenderer struct{}
+
+func (cycloneDXJSONRenderer) Render(ctx RenderContext) (int, error) {
+	vulns := make([]cdxVulnerability, 0, len(ctx.Records))
+	for _, r := range ctx.Records {
+		vulns = append(vulns, cdxVulnerability{
+			ID: r.CVEID,
+			Affects: []cdxAffects{{
+				Ref: r.RepositoryName + "@" + r.Digest,
+			}},
+			Ratings:     []cdxRating{{Severity: r.Severity, Score: r.CVSSScoreV3}},
+			Description: r.Description,
+		})
+	}
+	doc := cdxDocument{
+		BOMFormat:       "CycloneDX",
+		SpecVersion:     "1.5",
+		Version:         1,
+		Vulnerabilities: vulns,
+	}
+	return len(vulns), json.NewEncoder(ctx.Writer).Encode(doc)
+}
+
+type cdxDocument struct {
+	BOMFormat       string             `json:"bomFormat"`
+	SpecVersion     string             `json:"specVersion"`
+	Version         int                `json:"version"`
+	Vulnerabilities []cdxVulnerability `json:"vulnerabilities"`
+}
+
+type cdxVulnerability struct {
+	ID          string       `json:"id"`
+	Affects     []cdxAffects `json:"affects"`
+	Ratings     []cdxRating  `json:"ratings"`
+	Description string       `json:"description"`
+}
+
+type cdxAffects struct {
+	Ref string `json:"ref"`
+}
+
+type cdxRating struct {
+	Severity string  `json:"severity"`
+	Score    float64 `json:"score"`
+}
+
+func joinTags(tags []string) string {
+	return strings.Join(tags, ",")
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case "Critical", "High":
+		return "error"
+	case "Medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
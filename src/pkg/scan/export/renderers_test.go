@@ -0,0 +1,149 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testRecords = []VulnerabilityRecord{
+	{
+		ProjectName:    "library",
+		RepositoryName: "library/photon",
+		Digest:         "sha256:abc",
+		Tags:           []string{"latest", "v1"},
+		CVEID:          "CVE-2024-0001",
+		Package:        "openssl",
+		Version:        "1.0.0",
+		FixedVersion:   "1.0.1",
+		Severity:       "Critical",
+		CVSSScoreV3:    9.8,
+		Description:    "example vulnerability",
+	},
+	{
+		ProjectName:    "library",
+		RepositoryName: "library/photon",
+		Digest:         "sha256:abc",
+		Tags:           []string{"latest"},
+		CVEID:          "CVE-2024-0002",
+		Package:        "glibc",
+		Version:        "2.0.0",
+		FixedVersion:   "",
+		Severity:       "Medium",
+		CVSSScoreV3:    5.3,
+		Description:    "another vulnerability",
+	},
+}
+
+func TestCSVRendererRender(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := csvRenderer{}.Render(RenderContext{Records: testRecords, Writer: &buf})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if n != len(testRecords) {
+		t.Fatalf("Render returned %d, want %d", n, len(testRecords))
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse rendered CSV: %v", err)
+	}
+	if len(rows) != len(testRecords)+1 {
+		t.Fatalf("got %d rows, want %d (including header)", len(rows), len(testRecords)+1)
+	}
+	if !reflectEqual(rows[0], csvHeader) {
+		t.Errorf("header row = %v, want %v", rows[0], csvHeader)
+	}
+	if rows[1][4] != "CVE-2024-0001" || rows[1][3] != "latest,v1" {
+		t.Errorf("unexpected first data row: %v", rows[1])
+	}
+}
+
+func TestSarifRendererRender(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := sarifRenderer{}.Render(RenderContext{Records: testRecords, Writer: &buf})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if n != len(testRecords) {
+		t.Fatalf("Render returned %d, want %d", n, len(testRecords))
+	}
+	var doc sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to parse rendered SARIF: %v", err)
+	}
+	if len(doc.Runs) != 1 || len(doc.Runs[0].Results) != len(testRecords) {
+		t.Fatalf("unexpected SARIF structure: %+v", doc)
+	}
+	if doc.Runs[0].Results[0].Level != "error" {
+		t.Errorf("Critical severity should map to SARIF level \"error\", got %q", doc.Runs[0].Results[0].Level)
+	}
+	if doc.Runs[0].Results[1].Level != "warning" {
+		t.Errorf("Medium severity should map to SARIF level \"warning\", got %q", doc.Runs[0].Results[1].Level)
+	}
+}
+
+func TestSPDXJSONRendererDedupesByRepositoryAndDigest(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := spdxJSONRenderer{}.Render(RenderContext{Records: testRecords, Writer: &buf})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("Render returned %d packages, want 1 (both records share a repository/digest)", n)
+	}
+	var doc spdxDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to parse rendered SPDX document: %v", err)
+	}
+	if len(doc.Packages) != 1 || doc.Packages[0].Name != "library/photon" {
+		t.Errorf("unexpected SPDX packages: %+v", doc.Packages)
+	}
+}
+
+func TestCycloneDXJSONRendererRender(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := cycloneDXJSONRenderer{}.Render(RenderContext{Records: testRecords, Writer: &buf})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if n != len(testRecords) {
+		t.Fatalf("Render returned %d, want %d", n, len(testRecords))
+	}
+	var doc cdxDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to parse rendered CycloneDX document: %v", err)
+	}
+	if len(doc.Vulnerabilities) != len(testRecords) {
+		t.Fatalf("got %d vulnerabilities, want %d", len(doc.Vulnerabilities), len(testRecords))
+	}
+	if doc.Vulnerabilities[0].ID != "CVE-2024-0001" {
+		t.Errorf("unexpected first vulnerability ID: %q", doc.Vulnerabilities[0].ID)
+	}
+}
+
+func TestJoinTagsAndFormatScore(t *testing.T) {
+	if got := joinTags([]string{"a", "b"}); got != "a,b" {
+		t.Errorf("joinTags = %q, want %q", got, "a,b")
+	}
+	if got := joinTags(nil); got != "" {
+		t.Errorf("joinTags(nil) = %q, want empty string", got)
+	}
+	if got := formatScore(9.8); !strings.HasPrefix(got, "9.8") {
+		t.Errorf("formatScore(9.8) = %q, want prefix \"9.8\"", got)
+	}
+}
+
+func reflectEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
+
+// vulnerabilityRecordsLoader gathers the vulnerability scan data for the
+// projects named in request. It is a package-level variable rather than a
+// direct call into the vulnerability report store so it can be swapped out
+// in tests.
+var vulnerabilityRecordsLoader = loadVulnerabilityRecords
+
+// loadVulnerabilityRecords flattens the vulnerability scan reports of every
+// scanned artifact in request.Projects into one VulnerabilityRecord per
+// finding, the same scan data backing the project vulnerability list API.
+func loadVulnerabilityRecords(ctx context.Context, request Request) ([]VulnerabilityRecord, error) {
+	var records []VulnerabilityRecord
+	for _, projectID := range request.Projects {
+		query := q.New(q.KeyWords{"ProjectID": projectID})
+		artifacts, err := artifact.Ctl.List(ctx, query, &artifact.Option{
+			WithTag:          true,
+			WithScanOverview: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range artifacts {
+			records = append(records, recordsForArtifact(art)...)
+		}
+	}
+	return records, nil
+}
+
+func recordsForArtifact(art *artifact.Artifact) []VulnerabilityRecord {
+	var tags []string
+	for _, t := range art.Tags {
+		tags = append(tags, t.Name)
+	}
+	var records []VulnerabilityRecord
+	for _, raw := range art.ScanOverview {
+		// ScanOverview is keyed by report mime type; values are stored as
+		// interface{} because different scanners can report in different
+		// shapes, so the native (non-SBOM) summary has to be asserted out.
+		overview, ok := raw.(*vuln.NativeReportSummary)
+		if !ok {
+			continue
+		}
+		for _, item := range overview.Vulnerabilities {
+			records = append(records, VulnerabilityRecord{
+				ProjectName:    art.ProjectName,
+				RepositoryName: art.RepositoryName,
+				Digest:         art.Digest,
+				Tags:           tags,
+				CVEID:          item.ID,
+				Package:        item.Package,
+				Version:        item.Version,
+				FixedVersion:   item.FixVersion,
+				Severity:       item.Severity.String(),
+				CVSSScoreV3:    cvssV3Score(item),
+				Description:    item.Description,
+			})
+		}
+	}
+	return records
+}
+
+// cvssV3Score extracts the NVD CVSS v3 score for item, if the scanner
+// reported one.
+func cvssV3Score(item vuln.VulnerabilityItem) float64 {
+	if item.CVSSDetails.ScoreV3 == nil {
+		return 0
+	}
+	return *item.CVSSDetails.ScoreV3
+}